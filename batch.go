@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// readURLs reads one URL per line from path, or from stdin if path is "-".
+// Blank lines and lines starting with '#' are ignored.
+func readURLs(path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open URL list %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list %s: %w", path, err)
+	}
+	return urls, nil
+}
+
+// runBatch processes every URL in urlsFile through the pipeline using a
+// worker pool of the given concurrency, recording per-video progress in
+// outputBaseDir's manifest.json so a --resume run skips completed stages.
+// Cancelling ctx (e.g. on SIGINT) stops workers from picking up new URLs but
+// lets in-flight videos finish their current stage before returning.
+func runBatch(ctx context.Context, logger *slog.Logger, videoSource VideoSource, subtitleSource SubtitleSource, muxer Muxer, openaiClient *openai.Client, translateCfg translateConfig, urlsFile, outputBaseDir string, keepWorkDir bool, concurrency int, resume bool, manifest *Manifest) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	urls, err := readURLs(urlsFile)
+	if err != nil {
+		return err
+	}
+	logger.Info("Starting batch processing", "videos", len(urls), "concurrency", concurrency)
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	var failures int32
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			workerLogger := logger.With("worker", worker)
+
+			for url := range jobs {
+				// Derived from context.WithoutCancel(ctx) rather than ctx itself: a
+				// SIGINT must stop the feed loop below from handing out new URLs,
+				// but it must not abort a video already in flight mid-stage.
+				videoCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 1*time.Hour)
+				path, err := processVideoPipeline(videoCtx, workerLogger, videoSource, subtitleSource, muxer, openaiClient, translateCfg, url, outputBaseDir, keepWorkDir, manifest, resume)
+				cancel()
+				if err != nil {
+					workerLogger.Error("Video failed", "url", url, "error", err)
+					atomic.AddInt32(&failures, 1)
+					continue
+				}
+				workerLogger.Info("Video finished", "url", url, "path", path)
+			}
+		}(w)
+	}
+
+feed:
+	for _, url := range urls {
+		select {
+		case jobs <- url:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("batch processing interrupted: %w", ctx.Err())
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d/%d videos failed", failures, len(urls))
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// crc32File computes the IEEE CRC32 checksum of the file at path. It is run
+// as a second pass over the finished file rather than wrapped around
+// ffmpeg's output, since ffmpeg writes to its output path directly.
+func crc32File(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	hasher := crc32.NewIEEE()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return 0, err
+	}
+	return hasher.Sum32(), nil
+}
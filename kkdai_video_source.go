@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kkdai/youtube/v2"
+)
+
+// KkdaiVideoSource downloads video+audio natively in Go using
+// github.com/kkdai/youtube/v2, with no dependency on the yt-dlp binary. It
+// prefers a single itag carrying both video and audio; when YouTube only
+// offers split streams for the best quality, it downloads video and audio
+// separately and muxes them with ffmpeg.
+type KkdaiVideoSource struct{}
+
+// Name implements VideoSource.
+func (s *KkdaiVideoSource) Name() string { return "kkdai" }
+
+// Download implements VideoSource.
+func (s *KkdaiVideoSource) Download(ctx context.Context, logger *slog.Logger, videoID, videoURL, workDir string) (string, error) {
+	logger = logger.With("step", "downloadVideo", "source", s.Name(), "url", videoURL)
+	logger.Info("Starting video download")
+
+	client := youtube.Client{}
+
+	video, err := client.GetVideoContext(ctx, videoID)
+	if err != nil {
+		return "", fmt.Errorf("kkdai/youtube: failed to fetch video info: %w", err)
+	}
+
+	if combined := bestCombinedFormat(video.Formats); combined != nil {
+		outputPath := filepath.Join(workDir, videoID+".mp4")
+		if err := s.downloadFormat(ctx, &client, video, combined, outputPath); err != nil {
+			return "", fmt.Errorf("kkdai/youtube: failed to download combined stream: %w", err)
+		}
+		logger.Info("Downloaded combined video+audio stream", "itag", combined.ItagNo, "path", outputPath)
+		return outputPath, nil
+	}
+
+	logger.Info("No combined format available, downloading video and audio separately")
+
+	var videoOnly, audioOnly youtube.FormatList
+	for _, f := range video.Formats {
+		switch {
+		case f.Width > 0 && f.AudioChannels == 0:
+			videoOnly = append(videoOnly, f)
+		case f.Width == 0 && f.AudioChannels > 0:
+			audioOnly = append(audioOnly, f)
+		}
+	}
+	if len(videoOnly) == 0 {
+		return "", fmt.Errorf("kkdai/youtube: no video-only format available")
+	}
+	if len(audioOnly) == 0 {
+		return "", fmt.Errorf("kkdai/youtube: no audio-only format available")
+	}
+
+	videoPath := filepath.Join(workDir, videoID+"_video.mp4")
+	audioPath := filepath.Join(workDir, videoID+"_audio.m4a")
+
+	bestVideo := bestFormat(videoOnly)
+	bestAudio := bestFormat(audioOnly)
+	if err := s.downloadFormat(ctx, &client, video, &bestVideo, videoPath); err != nil {
+		return "", fmt.Errorf("kkdai/youtube: failed to download video-only stream: %w", err)
+	}
+	if err := s.downloadFormat(ctx, &client, video, &bestAudio, audioPath); err != nil {
+		return "", fmt.Errorf("kkdai/youtube: failed to download audio-only stream: %w", err)
+	}
+
+	outputPath := filepath.Join(workDir, videoID+".mp4")
+	if err := checkExecutable(logger, ffmpegExecutable); err != nil {
+		return "", err
+	}
+	muxArgs := []string{
+		"-i", videoPath,
+		"-i", audioPath,
+		"-c", "copy",
+		"-y",
+		outputPath,
+	}
+	if _, err := runCommand(ctx, logger, ffmpegExecutable, muxArgs...); err != nil {
+		return "", fmt.Errorf("ffmpeg failed to mux video and audio streams: %w", err)
+	}
+
+	logger.Info("Downloaded and muxed separate video/audio streams", "path", outputPath)
+	return outputPath, nil
+}
+
+// downloadFormat streams a single format to destPath.
+func (s *KkdaiVideoSource) downloadFormat(ctx context.Context, client *youtube.Client, video *youtube.Video, format *youtube.Format, destPath string) error {
+	stream, _, err := client.GetStreamContext(ctx, video, format)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, stream); err != nil {
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// bestCombinedFormat returns the highest-bitrate mp4 format carrying both
+// video and audio, or nil if none exists.
+func bestCombinedFormat(formats youtube.FormatList) *youtube.Format {
+	var combined youtube.FormatList
+	for _, f := range formats {
+		if strings.HasPrefix(f.MimeType, "video/mp4") && f.AudioChannels > 0 && f.Width > 0 {
+			combined = append(combined, f)
+		}
+	}
+	if len(combined) == 0 {
+		return nil
+	}
+	best := bestFormat(combined)
+	return &best
+}
+
+// bestFormat picks the highest-bitrate format from a non-empty list.
+func bestFormat(formats youtube.FormatList) youtube.Format {
+	best := formats[0]
+	for _, f := range formats[1:] {
+		if f.Bitrate > best.Bitrate {
+			best = f
+		}
+	}
+	return best
+}
@@ -6,23 +6,58 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
 	"time"
+
+	openai "github.com/sashabaranov/go-openai"
 )
 
 func main() {
 	// --- Configuration ---
-	videoURL := flag.String("url", "", "YouTube video URL (required)")
+	videoURL := flag.String("url", "", "YouTube video URL (required unless -urls is given)")
+	urlsFile := flag.String("urls", "", "Path to a file of YouTube URLs to process in batch, one per line ('-' for stdin)")
+	concurrency := flag.Int("concurrency", 1, "Number of videos to process concurrently in batch mode")
+	resume := flag.Bool("resume", false, "Resume from outputDir/manifest.json, skipping pipeline stages already completed")
 	outputDir := flag.String("output", "./output", "Directory for final processed video")
 	keepWorkDir := flag.Bool("keep-workdir", true, "Keep the temporary working directory after processing")
+	source := flag.String("source", "yt-dlp", "VideoSource to download with (yt-dlp, kkdai)")
+	container := flag.String("container", ContainerMP4, "Output container format (mp4, mkv)")
+	subs := flag.String("subs", SubsBurn, "How to attach subtitles to the output (burn, soft)")
+	openAIModel := flag.String("openai-model", "gpt-4o-mini", "OpenAI model used for subtitle translation")
+	translateBatchChars := flag.Int("translate-batch-chars", defaultTranslateBatchChars, "Approximate character budget per translation request")
+	translateContextCues := flag.Int("translate-context-cues", defaultTranslateContextCues, "Number of previously translated cues carried into the next batch for terminology consistency")
+	subtitleSourceNames := flag.String("subtitle-sources", "transcript,ytdlp,whisper", "Ordered, comma-separated chain of subtitle sources to try (transcript, ytdlp, whisper)")
+	whisperBackend := flag.String("whisper-backend", "local", "ASR backend for the whisper subtitle source (local, openai)")
 	logLevelStr := flag.String("log-level", os.Getenv("LOG_LEVEL"), "Log level (DEBUG, INFO, WARN, ERROR). Overrides LOG_LEVEL env var.")
 	flag.Parse()
 
-	if *videoURL == "" {
-		fmt.Println("Error: -url flag is required")
+	if *videoURL == "" && *urlsFile == "" {
+		fmt.Println("Error: either -url or -urls is required")
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	videoSource, err := getVideoSource(*source)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
+	if openAIAPIKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable is required")
+		os.Exit(1)
+	}
+	openaiClient := openai.NewClient(openAIAPIKey)
+
+	subtitleSource, err := buildSubtitleSourceChain(strings.Split(*subtitleSourceNames, ","), *whisperBackend, openaiClient)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// --- Logging Setup ---
 	var logLevel slog.Level
 	switch *logLevelStr {
@@ -42,16 +77,56 @@ func main() {
 
 	logger.Info("Starting YouTube processing pipeline",
 		"url", *videoURL,
+		"urls", *urlsFile,
+		"concurrency", *concurrency,
+		"resume", *resume,
 		"outputDir", *outputDir,
 		"keepWorkDir", *keepWorkDir,
+		"source", videoSource.Name(),
+		"container", *container,
+		"subs", *subs,
 		"logLevel", logLevel.String(),
 	)
 
-	// --- Main Processing Logic ---
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour) // Add a global timeout
+	// Cancel on SIGINT so a batch run stops launching new work, lets
+	// in-flight videos finish their current stage, and leaves the manifest
+	// in a consistent, resumable state.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	muxer := &FfmpegMuxer{Container: *container, Subs: *subs}
+	translateCfg := translateConfig{
+		model:       *openAIModel,
+		batchChars:  *translateBatchChars,
+		contextCues: *translateContextCues,
+	}
+
+	if err := ensureDir(logger, *outputDir); err != nil {
+		logger.Error("Failed to ensure base output directory", "path", *outputDir, "error", err)
+		os.Exit(1)
+	}
+
+	manifest, err := loadManifest(*outputDir)
+	if err != nil {
+		logger.Error("Failed to load manifest", "error", err)
+		os.Exit(1)
+	}
+
+	if *urlsFile != "" {
+		if err := runBatch(ctx, logger, videoSource, subtitleSource, muxer, openaiClient, translateCfg, *urlsFile, *outputDir, *keepWorkDir, *concurrency, *resume, manifest); err != nil {
+			logger.Error("Batch processing failed", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("Batch processing completed")
+		return
+	}
+
+	// Derived from context.WithoutCancel(ctx), not ctx itself, so a SIGINT
+	// lets this video finish its current stage instead of aborting it mid-run.
+	videoCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 1*time.Hour)
 	defer cancel()
 
-	finalPath, err := processVideoPipeline(ctx, logger, *videoURL, *outputDir, *keepWorkDir)
+	finalPath, err := processVideoPipeline(videoCtx, logger, videoSource, subtitleSource, muxer, openaiClient, translateCfg, *videoURL, *outputDir, *keepWorkDir, manifest, *resume)
 	if err != nil {
 		logger.Error("Video processing pipeline failed", "error", err)
 		os.Exit(1)
@@ -60,8 +135,12 @@ func main() {
 	logger.Info("Pipeline completed successfully!", "finalVideoPath", finalPath)
 }
 
-// processVideoPipeline orchestrates the entire workflow.
-func processVideoPipeline(ctx context.Context, logger *slog.Logger, videoURL, outputBaseDir string, keepWorkDir bool) (string, error) {
+// processVideoPipeline orchestrates the entire workflow for a single video.
+// When resume is true, each stage first checks manifest for a prior
+// successful run and, if its output is still on disk, skips straight to the
+// next stage; otherwise it runs the stage and records success (or failure)
+// in the manifest before continuing.
+func processVideoPipeline(ctx context.Context, logger *slog.Logger, videoSource VideoSource, subtitleSource SubtitleSource, muxer Muxer, openaiClient *openai.Client, translateCfg translateConfig, videoURL, outputBaseDir string, keepWorkDir bool, manifest *Manifest, resume bool) (string, error) {
 	startTime := time.Now()
 
 	// 1. Get Video ID and create working directory
@@ -73,9 +152,12 @@ func processVideoPipeline(ctx context.Context, logger *slog.Logger, videoURL, ou
 	logger = logger.With("videoID", videoID) // Add videoID to all subsequent logs
 	logger.Info("Extracted video ID")
 
-	// Create a base directory for all processing artifacts if it doesn't exist
-	if err := ensureDir(logger, outputBaseDir); err != nil {
-		return "", fmt.Errorf("failed to ensure base output directory %s: %w", outputBaseDir, err)
+	state := manifest.State(videoID)
+	if resume && state.Merged && state.FinalPath != "" {
+		if _, statErr := os.Stat(state.FinalPath); statErr == nil {
+			logger.Info("Skipping video, already merged (resume)", "path", state.FinalPath)
+			return state.FinalPath, nil
+		}
 	}
 
 	// Create a unique working directory for this specific video inside the base output dir
@@ -98,26 +180,72 @@ func processVideoPipeline(ctx context.Context, logger *slog.Logger, videoURL, ou
 	}
 
 	// 2. Download Video
-	videoPath, err := downloadVideo(ctx, logger, videoID, videoURL, workDir)
-	if err != nil {
-		return "", fmt.Errorf("step 1: download video failed: %w", err)
+	videoPath := filepath.Join(workDir, videoID+".mp4")
+	if !(resume && state.Downloaded && fileExists(videoPath)) {
+		videoPath, err = videoSource.Download(ctx, logger, videoID, videoURL, workDir)
+		if err != nil {
+			_ = manifest.Update(videoID, func(s *VideoState) { s.Error = err.Error() })
+			return "", fmt.Errorf("step 1: download video failed: %w", err)
+		}
+		if err := manifest.Update(videoID, func(s *VideoState) { s.Downloaded = true; s.Error = "" }); err != nil {
+			return "", fmt.Errorf("failed to update manifest: %w", err)
+		}
+	} else {
+		logger.Info("Skipping video download (resume)", "path", videoPath)
 	}
 
 	// 3. Download Subtitles
-	srtPath, err := downloadSubtitles(ctx, logger, videoID, workDir)
-	if err != nil {
-		// Consider if this should be a fatal error. Maybe the user wants the video even without subs?
-		// For this flow, we assume subtitles are required.
-		return "", fmt.Errorf("step 2: download subtitles failed: %w", err)
+	srtPath, foundExisting := "", false
+	if resume && state.Subtitled {
+		srtPath, foundExisting = findExistingSubtitle(workDir, videoID)
+	}
+	if !foundExisting {
+		srtPath, err = subtitleSource.Download(ctx, logger, videoID, workDir)
+		if err != nil {
+			// Consider if this should be a fatal error. Maybe the user wants the video even without subs?
+			// For this flow, we assume subtitles are required.
+			_ = manifest.Update(videoID, func(s *VideoState) { s.Error = err.Error() })
+			return "", fmt.Errorf("step 2: download subtitles failed: %w", err)
+		}
+		if err := manifest.Update(videoID, func(s *VideoState) { s.Subtitled = true; s.Error = "" }); err != nil {
+			return "", fmt.Errorf("failed to update manifest: %w", err)
+		}
+	} else {
+		logger.Info("Skipping subtitle download (resume)", "path", srtPath)
+	}
+
+	// 4. Translate Subtitles
+	translatedSrtPath := filepath.Join(workDir, fmt.Sprintf("%s_%s_translated.srt", videoID, targetLang))
+	if !(resume && state.Translated && fileExists(translatedSrtPath)) {
+		translatedSrtPath, err = translateSubtitles(ctx, logger, openaiClient, translateCfg.model, videoID, srtPath, workDir, translateCfg.batchChars, translateCfg.contextCues)
+		if err != nil {
+			_ = manifest.Update(videoID, func(s *VideoState) { s.Error = err.Error() })
+			return "", fmt.Errorf("step 3: translate subtitles failed: %w", err)
+		}
+		if err := manifest.Update(videoID, func(s *VideoState) { s.Translated = true; s.Error = "" }); err != nil {
+			return "", fmt.Errorf("failed to update manifest: %w", err)
+		}
+	} else {
+		logger.Info("Skipping subtitle translation (resume)", "path", translatedSrtPath)
 	}
 
-	// 4. Merge Video and Subtitles
+	// 5. Merge Video and Subtitles
 	// Place the final merged file directly into the user-specified outputBaseDir
-	finalVideoPath, err := mergeVideoSubtitles(ctx, logger, videoPath, srtPath, outputBaseDir, videoID)
+	finalVideoPath, crc, err := muxer.Merge(ctx, logger, videoPath, translatedSrtPath, outputBaseDir, videoID)
 	if err != nil {
+		_ = manifest.Update(videoID, func(s *VideoState) { s.Error = err.Error() })
 		return "", fmt.Errorf("step 4: merge video and subtitles failed: %w", err)
 	}
 
+	if err := manifest.Update(videoID, func(s *VideoState) {
+		s.Merged = true
+		s.FinalPath = finalVideoPath
+		s.Error = ""
+		s.CRC32 = fmt.Sprintf("%08X", crc)
+	}); err != nil {
+		return "", fmt.Errorf("failed to update manifest: %w", err)
+	}
+
 	// If we are keeping the work directory, the original downloaded video is still there.
 	// If we are *not* keeping the work directory, the original video download will be deleted by the deferred cleanup.
 	// The final output is placed *outside* the workDir (in outputBaseDir), so it's always preserved.
@@ -125,3 +253,9 @@ func processVideoPipeline(ctx context.Context, logger *slog.Logger, videoURL, ou
 	logger.Info("Processing finished", "totalDuration", time.Since(startTime))
 	return finalVideoPath, nil
 }
+
+// fileExists reports whether path exists and is a regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
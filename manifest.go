@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// VideoState tracks per-stage progress for one video across pipeline runs,
+// so a --resume run can skip stages that already completed successfully.
+type VideoState struct {
+	Downloaded bool   `json:"downloaded"`
+	Subtitled  bool   `json:"subtitled"`
+	Translated bool   `json:"translated"`
+	Merged     bool   `json:"merged"`
+	FinalPath  string `json:"finalPath,omitempty"`
+	CRC32      string `json:"crc32,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Manifest records pipeline progress for every video processed into a given
+// output directory, persisted as manifest.json. It is safe for concurrent
+// use by a batch worker pool; every mutation is flushed to disk immediately
+// so a killed process loses at most the in-flight stage.
+type Manifest struct {
+	path string
+
+	mu     sync.Mutex
+	Videos map[string]*VideoState `json:"videos"`
+}
+
+// loadManifest reads manifest.json from dir, or returns an empty manifest if
+// one doesn't exist yet.
+func loadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, "manifest.json")
+	m := &Manifest{path: path, Videos: make(map[string]*VideoState)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Videos == nil {
+		m.Videos = make(map[string]*VideoState)
+	}
+	return m, nil
+}
+
+// State returns a copy of videoID's current state (the zero value if unseen).
+func (m *Manifest) State(videoID string) VideoState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.Videos[videoID]; ok {
+		return *s
+	}
+	return VideoState{}
+}
+
+// Update applies fn to videoID's state and atomically persists the manifest.
+func (m *Manifest) Update(videoID string, fn func(*VideoState)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.Videos[videoID]
+	if !ok {
+		s = &VideoState{}
+		m.Videos[videoID] = s
+	}
+	fn(s)
+
+	return m.save()
+}
+
+// save writes the manifest to a temp file and renames it into place, so a
+// reader never observes a partially-written manifest.
+func (m *Manifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	tmpPath := m.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, m.path); err != nil {
+		return fmt.Errorf("failed to rename manifest into place: %w", err)
+	}
+	return nil
+}
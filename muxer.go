@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+const (
+	ffmpegExecutable = "ffmpeg"
+
+	// Container options for FfmpegMuxer.Container.
+	ContainerMP4 = "mp4"
+	ContainerMKV = "mkv"
+
+	// Subtitle handling options for FfmpegMuxer.Subs.
+	SubsBurn = "burn" // re-encode subtitles into the video stream
+	SubsSoft = "soft" // mux subtitles as a selectable, lossless track
+)
+
+// Muxer combines a video file and a subtitle file into the final output
+// file placed in outputDir.
+type Muxer interface {
+	// Merge produces the final output file and returns its path along with
+	// its CRC32 checksum, so callers don't have to re-hash the file to learn
+	// the value already embedded in its filename.
+	Merge(ctx context.Context, logger *slog.Logger, videoPath, subtitlePath, outputDir, videoID string) (string, uint32, error)
+}
+
+// FfmpegMuxer uses the ffmpeg binary to combine video and subtitles.
+// Container selects the output container (mp4 or mkv); Subs selects whether
+// subtitles are burned into the video or kept as a soft, switchable track.
+// The final output file is streamed back through a CRC32 hasher so its
+// filename embeds an integrity tag, e.g. "videoID_final_[A1B2C3D4].mkv".
+type FfmpegMuxer struct {
+	Container string
+	Subs      string
+}
+
+// Merge implements Muxer.
+func (m *FfmpegMuxer) Merge(ctx context.Context, logger *slog.Logger, videoPath, subtitlePath, outputDir, videoID string) (string, uint32, error) {
+	container := m.Container
+	if container == "" {
+		container = ContainerMP4
+	}
+	subsMode := m.Subs
+	if subsMode == "" {
+		subsMode = SubsBurn
+	}
+
+	logger = logger.With("step", "mergeVideoSubtitles", "video", videoPath, "subtitles", subtitlePath, "container", container, "subs", subsMode)
+	logger.Info("Starting video and subtitle merge")
+
+	// Ensure ffmpeg exists
+	if err := checkExecutable(logger, ffmpegExecutable); err != nil {
+		return "", 0, err
+	}
+
+	// Write to a temporary path first; the final name is only known once the
+	// CRC32 of the finished file has been computed.
+	tmpOutputPath := filepath.Join(outputDir, fmt.Sprintf("%s_final.%s.tmp", videoID, container))
+
+	// Check if the subtitle file is empty. If so, don't add subtitle arguments.
+	subFileInfo, err := os.Stat(subtitlePath)
+	isEmptySubtitle := err == nil && subFileInfo.Size() == 0
+
+	var args []string
+	switch {
+	case isEmptySubtitle:
+		logger.Warn("Subtitle file is empty, copying video without subtitles")
+		args = []string{
+			"-i", videoPath,
+			"-c", "copy", // Copy existing streams without re-encoding
+			"-y",
+			tmpOutputPath,
+		}
+	case subsMode == SubsSoft:
+		args = []string{
+			"-i", videoPath,
+			"-i", subtitlePath,
+			"-c", "copy", // Copy video and audio streams without re-encoding
+			"-c:s", softSubtitleCodec(container),
+			"-y",
+			tmpOutputPath,
+		}
+	default: // SubsBurn
+		args = []string{
+			"-i", videoPath,
+			"-c:a", "copy", // Copy audio stream without re-encoding
+			"-vf", fmt.Sprintf("subtitles=%s:force_style='FontSize=16,Alignment=2'", subtitlePath), // Burn subtitles into video
+			"-y", // Overwrite output
+			tmpOutputPath,
+		}
+	}
+
+	// Execute the command
+	if _, err := runCommand(ctx, logger, ffmpegExecutable, args...); err != nil {
+		// Attempt to remove potentially incomplete output file on error
+		_ = os.Remove(tmpOutputPath)
+		return "", 0, fmt.Errorf("ffmpeg execution failed: %w", err)
+	}
+
+	// Verify the merged file exists
+	if _, err := os.Stat(tmpOutputPath); err != nil {
+		logger.Error("ffmpeg command seemed successful, but output file not found", "path", tmpOutputPath, "error", err)
+		return "", 0, fmt.Errorf("ffmpeg finished, but expected output file '%s' was not found: %w", tmpOutputPath, err)
+	}
+
+	// Stamp the filename with the CRC32 of the finished file.
+	crc, err := crc32File(tmpOutputPath)
+	if err != nil {
+		_ = os.Remove(tmpOutputPath)
+		return "", 0, fmt.Errorf("failed to compute CRC32 of %s: %w", tmpOutputPath, err)
+	}
+
+	finalFileName := fmt.Sprintf("%s_final_[%08X].%s", videoID, crc, container)
+	finalOutputPath := filepath.Join(outputDir, finalFileName)
+	if err := os.Rename(tmpOutputPath, finalOutputPath); err != nil {
+		return "", 0, fmt.Errorf("failed to rename %s to %s: %w", tmpOutputPath, finalOutputPath, err)
+	}
+
+	logger.Info("Video and subtitles merged successfully", "path", finalOutputPath, "crc32", fmt.Sprintf("%08X", crc))
+	return finalOutputPath, crc, nil
+}
+
+// softSubtitleCodec returns the subtitle codec ffmpeg should mux a soft
+// subtitle track as for the given container.
+func softSubtitleCodec(container string) string {
+	if container == ContainerMKV {
+		return "srt"
+	}
+	return "mov_text" // mp4 containers require mov_text for soft subtitle tracks
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cue is a single subtitle entry parsed out of an SRT file.
+type Cue struct {
+	Index int
+	Start string // SRT timestamp, e.g. "00:00:01,000"
+	End   string
+	Text  string
+}
+
+// parseSRT parses the contents of an SRT file into a slice of Cue. Blocks
+// are separated by a blank line; a block is an index line, a
+// "start --> end" timestamp line, and one or more lines of text.
+func parseSRT(content string) ([]Cue, error) {
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	blocks := strings.Split(strings.TrimSpace(normalized), "\n\n")
+
+	cues := make([]Cue, 0, len(blocks))
+	for _, block := range blocks {
+		block = strings.TrimSpace(block)
+		if block == "" {
+			continue
+		}
+
+		lines := strings.Split(block, "\n")
+		if len(lines) < 2 {
+			return nil, fmt.Errorf("malformed SRT block (expected at least an index and timestamp line): %q", block)
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+		if err != nil {
+			return nil, fmt.Errorf("malformed SRT cue index %q: %w", lines[0], err)
+		}
+
+		start, end, ok := strings.Cut(lines[1], " --> ")
+		if !ok {
+			return nil, fmt.Errorf("malformed SRT timestamp line %q", lines[1])
+		}
+
+		cues = append(cues, Cue{
+			Index: index,
+			Start: strings.TrimSpace(start),
+			End:   strings.TrimSpace(end),
+			Text:  strings.Join(lines[2:], "\n"),
+		})
+	}
+
+	return cues, nil
+}
+
+// formatSRT re-serializes cues back into SRT format.
+func formatSRT(cues []Cue) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n", cue.Index, cue.Start, cue.End, cue.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// SubtitleSource fetches a subtitle track for a YouTube video ID into workDir
+// and returns the path to the resulting SRT file.
+type SubtitleSource interface {
+	// Name identifies the source for logging and the --subtitle-source flag.
+	Name() string
+	// Download fetches subtitles and returns the path to the SRT file.
+	Download(ctx context.Context, logger *slog.Logger, videoID, workDir string) (string, error)
+}
+
+// subtitleSources is the registry of available SubtitleSource implementations.
+var subtitleSources = map[string]SubtitleSource{}
+
+func registerSubtitleSource(s SubtitleSource) {
+	subtitleSources[s.Name()] = s
+}
+
+// getSubtitleSource looks up a registered SubtitleSource by name.
+func getSubtitleSource(name string) (SubtitleSource, error) {
+	s, ok := subtitleSources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown subtitle source %q", name)
+	}
+	return s, nil
+}
+
+func init() {
+	registerSubtitleSource(&TranscriptSubtitleSource{})
+	registerSubtitleSource(&YtDlpAutoSubsSubtitleSource{})
+}
+
+// buildSubtitleSourceChain resolves the ordered, comma-separated list of
+// subtitle source names from --subtitle-sources (e.g.
+// "transcript,ytdlp,whisper") into a ChainSubtitleSource. The whisper source
+// takes runtime configuration (ASR backend, OpenAI client) that the registry
+// can't hold, so it's constructed here rather than looked up.
+func buildSubtitleSourceChain(names []string, whisperBackend string, openaiClient *openai.Client) (SubtitleSource, error) {
+	sources := make([]SubtitleSource, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == "whisper" {
+			sources = append(sources, &WhisperSubtitleSource{Backend: whisperBackend, OpenAIClient: openaiClient})
+			continue
+		}
+		src, err := getSubtitleSource(name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no subtitle sources configured")
+	}
+	return &ChainSubtitleSource{Sources: sources}, nil
+}
+
+// ChainSubtitleSource tries each of its Sources in order and returns the
+// first one to succeed, so a video with disabled transcripts can still fall
+// back to auto-captions or ASR. A "<srt>.source" sidecar file records which
+// source actually produced the result.
+type ChainSubtitleSource struct {
+	Sources []SubtitleSource
+}
+
+// Name implements SubtitleSource.
+func (c *ChainSubtitleSource) Name() string { return "chain" }
+
+// Download implements SubtitleSource.
+func (c *ChainSubtitleSource) Download(ctx context.Context, logger *slog.Logger, videoID, workDir string) (string, error) {
+	var lastErr error
+	for _, src := range c.Sources {
+		srtPath, err := src.Download(ctx, logger, videoID, workDir)
+		if err != nil {
+			logger.Warn("Subtitle source failed, trying next", "source", src.Name(), "error", err)
+			lastErr = err
+			continue
+		}
+		if info, statErr := os.Stat(srtPath); statErr == nil && info.Size() == 0 {
+			logger.Warn("Subtitle source produced an empty file, trying next", "source", src.Name(), "path", srtPath)
+			lastErr = fmt.Errorf("%s: produced an empty subtitle file", src.Name())
+			continue
+		}
+
+		logger.Info("Subtitle source succeeded", "source", src.Name(), "path", srtPath)
+		sourceFile := srtPath + ".source"
+		if err := os.WriteFile(sourceFile, []byte(src.Name()+"\n"), 0644); err != nil {
+			logger.Warn("Failed to write subtitle provenance sidecar", "path", sourceFile, "error", err)
+		}
+		return srtPath, nil
+	}
+	return "", fmt.Errorf("all subtitle sources failed, last error: %w", lastErr)
+}
+
+const (
+	youtubeTranscriptApiExecutable = "youtube_transcript_api"
+	sourceLang                     = "en" // Language to download
+	targetLang                     = "zh" // Language to translate
+	targetFormat                   = "srt"
+)
+
+// TranscriptSubtitleSource downloads the source-language transcript by
+// shelling out to youtube_transcript_api.
+type TranscriptSubtitleSource struct{}
+
+// Name implements SubtitleSource.
+func (s *TranscriptSubtitleSource) Name() string { return "transcript" }
+
+// Download implements SubtitleSource.
+func (s *TranscriptSubtitleSource) Download(ctx context.Context, logger *slog.Logger, videoID, workDir string) (string, error) {
+	logger = logger.With("step", "downloadSubtitles", "source", s.Name(), "videoID", videoID)
+	logger.Info("Starting subtitle download")
+
+	// Ensure Python exists
+	if err := checkExecutable(logger, youtubeTranscriptApiExecutable); err != nil {
+		return "", err
+	}
+
+	// Define output path for the original English SRT file
+	originalSrtPath := filepath.Join(workDir, fmt.Sprintf("%s_%s.srt", videoID, sourceLang))
+
+	args := []string{
+		"--languages", sourceLang,
+		"--format", targetFormat,
+		videoID,
+	}
+
+	// Execute the command
+	if output, err := runCommand(ctx, logger, youtubeTranscriptApiExecutable, args...); err != nil {
+		return "", fmt.Errorf("youtube_transcript_api execution failed: %w", err)
+	} else {
+		if len(output) == 0 {
+			return "", fmt.Errorf("youtube_transcript_api returned empty output")
+		}
+		// Write the output to the original SRT file
+		if err := os.WriteFile(originalSrtPath, output, 0644); err != nil {
+			return "", fmt.Errorf("failed to write SRT file %s: %w", originalSrtPath, err)
+		}
+	}
+
+	// Verify the output file was created
+	if _, err := os.Stat(originalSrtPath); err != nil {
+		logger.Error("Subtitle script ran but output SRT file not found", "path", originalSrtPath, "error", err)
+		return "", fmt.Errorf("subtitle script finished, but expected SRT file '%s' was not created: %w", originalSrtPath, err)
+	}
+
+	logger.Info("Subtitles downloaded successfully", "path", originalSrtPath)
+	return originalSrtPath, nil
+}
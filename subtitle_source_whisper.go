@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	whisperCppExecutable = "whisper-cli" // whisper.cpp's CLI binary
+	whisperAudioSampleHz = "16000"
+)
+
+// WhisperSubtitleSource transcribes the video's own audio track with ASR,
+// for the videos where neither a transcript nor auto-captions are
+// available. It extracts a 16kHz mono WAV with ffmpeg and feeds it to one
+// of two backends: a local whisper.cpp binary, or OpenAI's
+// audio/transcriptions API.
+type WhisperSubtitleSource struct {
+	Backend      string // "local" (whisper.cpp) or "openai"
+	OpenAIClient *openai.Client
+}
+
+// Name implements SubtitleSource.
+func (s *WhisperSubtitleSource) Name() string { return "whisper" }
+
+// Download implements SubtitleSource.
+func (s *WhisperSubtitleSource) Download(ctx context.Context, logger *slog.Logger, videoID, workDir string) (string, error) {
+	logger = logger.With("step", "downloadSubtitles", "source", s.Name(), "videoID", videoID, "backend", s.Backend)
+	logger.Info("Starting subtitle extraction via ASR")
+
+	videoPath, err := findDownloadedVideo(workDir, videoID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkExecutable(logger, ffmpegExecutable); err != nil {
+		return "", err
+	}
+	audioPath := filepath.Join(workDir, videoID+"_audio.wav")
+	extractArgs := []string{
+		"-i", videoPath,
+		"-vn",
+		"-acodec", "pcm_s16le",
+		"-ar", whisperAudioSampleHz,
+		"-ac", "1",
+		"-y",
+		audioPath,
+	}
+	if _, err := runCommand(ctx, logger, ffmpegExecutable, extractArgs...); err != nil {
+		return "", fmt.Errorf("failed to extract audio for ASR: %w", err)
+	}
+
+	switch s.Backend {
+	case "openai":
+		return s.transcribeWithOpenAI(ctx, logger, audioPath, videoID, workDir)
+	default:
+		return s.transcribeWithWhisperCpp(ctx, logger, audioPath, videoID, workDir)
+	}
+}
+
+// transcribeWithWhisperCpp shells out to a local whisper.cpp binary.
+func (s *WhisperSubtitleSource) transcribeWithWhisperCpp(ctx context.Context, logger *slog.Logger, audioPath, videoID, workDir string) (string, error) {
+	if err := checkExecutable(logger, whisperCppExecutable); err != nil {
+		return "", err
+	}
+
+	outputPrefix := filepath.Join(workDir, videoID+"_whisper")
+	args := []string{
+		"-f", audioPath,
+		"-l", sourceLang,
+		"-osrt",
+		"-of", outputPrefix,
+	}
+	if _, err := runCommand(ctx, logger, whisperCppExecutable, args...); err != nil {
+		return "", fmt.Errorf("whisper.cpp execution failed: %w", err)
+	}
+
+	srtPath := outputPrefix + ".srt"
+	if _, err := os.Stat(srtPath); err != nil {
+		logger.Error("whisper.cpp ran but output SRT file not found", "path", srtPath, "error", err)
+		return "", fmt.Errorf("whisper.cpp finished, but expected SRT file '%s' was not created: %w", srtPath, err)
+	}
+
+	logger.Info("Subtitles transcribed successfully", "path", srtPath)
+	return srtPath, nil
+}
+
+// transcribeWithOpenAI calls OpenAI's audio/transcriptions endpoint.
+func (s *WhisperSubtitleSource) transcribeWithOpenAI(ctx context.Context, logger *slog.Logger, audioPath, videoID, workDir string) (string, error) {
+	if s.OpenAIClient == nil {
+		return "", fmt.Errorf("openai whisper backend selected but no OpenAI client was configured")
+	}
+
+	f, err := os.Open(audioPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audio file %s: %w", audioPath, err)
+	}
+	defer f.Close()
+
+	resp, err := s.OpenAIClient.CreateTranscription(ctx, openai.AudioRequest{
+		Model:    openai.Whisper1,
+		Reader:   f,
+		FilePath: filepath.Base(audioPath),
+		Format:   openai.AudioResponseFormatSRT,
+		Language: sourceLang,
+	})
+	if err != nil {
+		return "", fmt.Errorf("openai audio transcription failed: %w", err)
+	}
+
+	srtPath := filepath.Join(workDir, videoID+"_whisper.srt")
+	if err := os.WriteFile(srtPath, []byte(resp.Text), 0644); err != nil {
+		return "", fmt.Errorf("failed to write transcribed SRT file %s: %w", srtPath, err)
+	}
+
+	logger.Info("Subtitles transcribed successfully", "path", srtPath)
+	return srtPath, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// YtDlpAutoSubsSubtitleSource falls back to yt-dlp's auto-generated
+// captions for videos where youtube_transcript_api returns nothing (common
+// for videos with transcripts disabled).
+type YtDlpAutoSubsSubtitleSource struct{}
+
+// Name implements SubtitleSource.
+func (s *YtDlpAutoSubsSubtitleSource) Name() string { return "ytdlp" }
+
+// Download implements SubtitleSource.
+func (s *YtDlpAutoSubsSubtitleSource) Download(ctx context.Context, logger *slog.Logger, videoID, workDir string) (string, error) {
+	logger = logger.With("step", "downloadSubtitles", "source", s.Name(), "videoID", videoID)
+	logger.Info("Starting subtitle download via yt-dlp auto-captions")
+
+	if err := checkExecutable(logger, ytDlpExecutable); err != nil {
+		return "", err
+	}
+
+	outputTemplate := filepath.Join(workDir, fmt.Sprintf("%s.%%(ext)s", videoID))
+	args := []string{
+		"--write-auto-subs",
+		"--sub-langs", sourceLang,
+		"--skip-download",
+		"--convert-subs", targetFormat,
+		"-o", outputTemplate,
+		"--no-warnings",
+		fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
+	}
+
+	if _, err := runCommand(ctx, logger, ytDlpExecutable, args...); err != nil {
+		return "", fmt.Errorf("yt-dlp auto-subs execution failed: %w", err)
+	}
+
+	srtPath := filepath.Join(workDir, fmt.Sprintf("%s.%s.%s", videoID, sourceLang, targetFormat))
+	if _, err := os.Stat(srtPath); err != nil {
+		logger.Error("yt-dlp ran but auto-subs file not found", "path", srtPath, "error", err)
+		return "", fmt.Errorf("yt-dlp finished, but expected auto-subs file '%s' was not created: %w", srtPath, err)
+	}
+
+	logger.Info("Subtitles downloaded successfully", "path", srtPath)
+	return srtPath, nil
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	defaultTranslateBatchChars  = 2000 // approximate character budget per translation request
+	defaultTranslateContextCues = 5    // number of previously translated cues carried forward for terminology consistency
+
+	translateMaxRetries = 5
+	translateBaseDelay  = 2 * time.Second
+)
+
+// translateConfig bundles the translateSubtitles flags threaded through from main.go.
+type translateConfig struct {
+	model       string
+	batchChars  int
+	contextCues int
+}
+
+// translateSubtitles reads an SRT file, translates it in character-budgeted
+// batches via OpenAI, and saves the translated content to a new SRT file.
+// Batches are translated independently: a batch that fails after retries
+// falls back to its original text rather than aborting the whole video, and
+// the last few translated cues of each batch are carried into the next
+// batch's system prompt to keep terminology consistent.
+func translateSubtitles(ctx context.Context, logger *slog.Logger, openaiClient *openai.Client, openAIModel, videoID, originalSrtPath, workDir string, batchChars, contextCues int) (string, error) {
+	logger = logger.With("step", "translateSubtitles", "sourceSrt", originalSrtPath)
+	logger.Info("Starting subtitle translation")
+
+	translatedSrtPath := filepath.Join(workDir, fmt.Sprintf("%s_%s_translated.srt", videoID, targetLang))
+
+	// Read the original SRT content
+	srtContentBytes, err := os.ReadFile(originalSrtPath)
+	if err != nil {
+		logger.Error("Failed to read original SRT file", "error", err)
+		return "", fmt.Errorf("failed to read SRT file %s: %w", originalSrtPath, err)
+	}
+	srtContent := string(srtContentBytes)
+
+	if len(strings.TrimSpace(srtContent)) == 0 {
+		logger.Warn("Original SRT file is empty, skipping translation")
+		if err := os.WriteFile(translatedSrtPath, []byte{}, 0644); err != nil {
+			logger.Error("Failed to write empty translated SRT file", "path", translatedSrtPath, "error", err)
+			return "", fmt.Errorf("failed to write empty translated SRT file %s: %w", translatedSrtPath, err)
+		}
+		logger.Info("Created empty translated SRT file as original was empty", "path", translatedSrtPath)
+		return translatedSrtPath, nil
+	}
+
+	cues, err := parseSRT(srtContent)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SRT file %s: %w", originalSrtPath, err)
+	}
+
+	batches := batchCues(cues, batchChars)
+	logger.Info("Split subtitles into batches", "cues", len(cues), "batches", len(batches), "batchChars", batchChars)
+
+	positionByIndex := make(map[int]int, len(cues))
+	for pos, cue := range cues {
+		positionByIndex[cue.Index] = pos
+	}
+
+	var contextTail []Cue
+	for i, batch := range batches {
+		batchLogger := logger.With("batch", i+1, "of", len(batches), "cues", len(batch))
+
+		translated, err := translateBatch(ctx, batchLogger, openaiClient, openAIModel, batch, contextTail)
+		if err != nil {
+			batchLogger.Error("Failed to translate batch after retries, falling back to original text", "error", err)
+			translated = batch // fall back: keep the original English text for this batch
+		}
+
+		for _, cue := range translated {
+			cues[positionByIndex[cue.Index]].Text = cue.Text
+		}
+
+		if n := contextCues; n > 0 {
+			contextTail = lastCues(translated, n)
+		}
+	}
+
+	translatedContent := formatSRT(cues)
+	if err := os.WriteFile(translatedSrtPath, []byte(translatedContent), 0644); err != nil {
+		logger.Error("Failed to write translated SRT file", "path", translatedSrtPath, "error", err)
+		return "", fmt.Errorf("failed to write translated SRT file %s: %w", translatedSrtPath, err)
+	}
+
+	logger.Info("Subtitles translated successfully", "path", translatedSrtPath)
+	return translatedSrtPath, nil
+}
+
+// batchCues groups cues into batches whose combined text length stays under
+// maxChars, without splitting a single cue across batches.
+func batchCues(cues []Cue, maxChars int) [][]Cue {
+	var batches [][]Cue
+	var current []Cue
+	currentChars := 0
+
+	for _, cue := range cues {
+		if len(current) > 0 && currentChars+len(cue.Text) > maxChars {
+			batches = append(batches, current)
+			current = nil
+			currentChars = 0
+		}
+		current = append(current, cue)
+		currentChars += len(cue.Text)
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// translateBatch translates a single batch of cues, asking the model for a
+// JSON object mapping cue index to translated text so timestamps never need
+// to round-trip through it. It retries with exponential backoff on 429/5xx
+// responses.
+func translateBatch(ctx context.Context, logger *slog.Logger, client *openai.Client, model string, batch, contextTail []Cue) ([]Cue, error) {
+	systemPrompt := buildTranslateSystemPrompt(contextTail)
+	userPrompt := buildTranslateUserPrompt(batch)
+
+	var lastErr error
+	for attempt := 0; attempt <= translateMaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := translateBaseDelay * time.Duration(math.Pow(2, float64(attempt-1)))
+			logger.Warn("Retrying OpenAI translation request", "attempt", attempt, "delay", delay, "error", lastErr)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		resp, err := client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model: model,
+			Messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleSystem, Content: systemPrompt},
+				{Role: openai.ChatMessageRoleUser, Content: userPrompt},
+			},
+			Temperature:    0.2,
+			ResponseFormat: &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject},
+		})
+		if err != nil {
+			lastErr = err
+			if isRetryableOpenAIError(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+			lastErr = fmt.Errorf("openai returned an empty or invalid response")
+			continue
+		}
+
+		translations, err := parseTranslationResponse(resp.Choices[0].Message.Content)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to parse translation response: %w", err)
+			continue
+		}
+
+		translated := make([]Cue, len(batch))
+		for i, cue := range batch {
+			text, ok := translations[cue.Index]
+			if !ok {
+				lastErr = fmt.Errorf("translation response missing cue %d", cue.Index)
+				break
+			}
+			translated[i] = Cue{Index: cue.Index, Start: cue.Start, End: cue.End, Text: text}
+		}
+		if lastErr == nil {
+			return translated, nil
+		}
+	}
+
+	return nil, fmt.Errorf("translation batch failed after %d attempts: %w", translateMaxRetries+1, lastErr)
+}
+
+// buildTranslateSystemPrompt builds the system prompt, carrying the tail of
+// the previous batch's translation forward as terminology context.
+func buildTranslateSystemPrompt(contextTail []Cue) string {
+	prompt := fmt.Sprintf("You are a professional translator specialized in video subtitles. Translate the given English subtitle cues into %s. "+
+		"Respond with a single JSON object mapping each cue's index (as a string) to its translated text, and nothing else.", targetLang)
+
+	if len(contextTail) == 0 {
+		return prompt
+	}
+
+	var b strings.Builder
+	b.WriteString(prompt)
+	b.WriteString("\n\nFor terminology consistency, here are the most recently translated cues:\n")
+	for _, cue := range contextTail {
+		fmt.Fprintf(&b, "%d: %s\n", cue.Index, cue.Text)
+	}
+	return b.String()
+}
+
+// buildTranslateUserPrompt renders the batch of cues to translate as
+// "index: text" lines.
+func buildTranslateUserPrompt(batch []Cue) string {
+	var b strings.Builder
+	for _, cue := range batch {
+		fmt.Fprintf(&b, "%d: %s\n", cue.Index, cue.Text)
+	}
+	return b.String()
+}
+
+// parseTranslationResponse parses the model's {"index": "text"} JSON object
+// into a map keyed by cue index.
+func parseTranslationResponse(content string) (map[int]string, error) {
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(content), &raw); err != nil {
+		return nil, err
+	}
+
+	translations := make(map[int]string, len(raw))
+	for key, text := range raw {
+		index, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("non-numeric cue index %q in translation response", key)
+		}
+		translations[index] = text
+	}
+	return translations, nil
+}
+
+// isRetryableOpenAIError reports whether err represents a rate-limit (429)
+// or server-side (5xx) error worth retrying.
+func isRetryableOpenAIError(err error) bool {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode == 429 || apiErr.HTTPStatusCode >= 500
+	}
+	return false
+}
+
+// lastCues returns the final n cues of cues (or all of them, if fewer).
+func lastCues(cues []Cue, n int) []Cue {
+	if len(cues) <= n {
+		return cues
+	}
+	return cues[len(cues)-n:]
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -54,37 +53,6 @@ func checkExecutable(logger *slog.Logger, name string) error {
 	return nil
 }
 
-// getYoutubeVideoID extracts the video ID from various YouTube URL formats.
-func getYoutubeVideoID(rawURL string) (string, error) {
-	parsedURL, err := url.Parse(rawURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse URL '%s': %w", rawURL, err)
-	}
-
-	// Standard youtube.com format (youtube.com/watch?v=VIDEO_ID)
-	if strings.Contains(parsedURL.Host, "youtube.com") {
-		videoID := parsedURL.Query().Get("v")
-		if videoID != "" {
-			return videoID, nil
-		}
-	}
-
-	// Shortened youtu.be format (youtu.be/VIDEO_ID)
-	if strings.Contains(parsedURL.Host, "youtu.be") {
-		videoID := strings.TrimPrefix(parsedURL.Path, "/")
-		if videoID != "" {
-			// Remove potential query params like ?t=...
-			if idx := strings.Index(videoID, "?"); idx != -1 {
-				videoID = videoID[:idx]
-			}
-			return videoID, nil
-		}
-	}
-
-	// Handle other potential formats or return error
-	return "", fmt.Errorf("could not extract video ID from URL: %s", rawURL)
-}
-
 // getWorkDir creates a unique working directory for processing a video.
 func getWorkDir(baseDir, videoID string) (string, error) {
 	// Sanitize videoID for use in directory name if necessary, though usually safe.
@@ -96,3 +64,36 @@ func getWorkDir(baseDir, videoID string) (string, error) {
 	}
 	return workDir, nil
 }
+
+// findExistingSubtitle looks for a subtitle file already produced for
+// videoID by any SubtitleSource in the chain, for resuming without knowing
+// which source succeeded last time.
+func findExistingSubtitle(workDir, videoID string) (string, bool) {
+	files, err := filepath.Glob(filepath.Join(workDir, videoID+"*.srt"))
+	if err != nil || len(files) == 0 {
+		return "", false
+	}
+	for _, f := range files {
+		if !strings.Contains(f, "_translated") {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+// findDownloadedVideo locates the video file a VideoSource already
+// downloaded for videoID in workDir, for steps (like ASR) that need the
+// video itself rather than just its path threaded through from earlier.
+func findDownloadedVideo(workDir, videoID string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(workDir, videoID+".*"))
+	if err != nil {
+		return "", fmt.Errorf("failed to search for downloaded video: %w", err)
+	}
+	for _, f := range files {
+		switch strings.ToLower(filepath.Ext(f)) {
+		case ".mp4", ".mkv", ".webm", ".avi":
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("no downloaded video file found for %s in %s", videoID, workDir)
+}
@@ -10,11 +10,50 @@ import (
 	"strings"
 )
 
-const (
-	ytDlpExecutable = "yt-dlp"
+// VideoSource fetches the source video for a YouTube video ID into workDir
+// and returns the path to the resulting file. Implementations are free to
+// shell out to external tools or download natively; the pipeline only
+// depends on this interface.
+type VideoSource interface {
+	// Name identifies the source for logging and the --source flag.
+	Name() string
+	// Download fetches the video and returns the path to the downloaded file.
+	Download(ctx context.Context, logger *slog.Logger, videoID, videoURL, workDir string) (string, error)
+}
 
-	videoFormat = "bestvideo[ext=mp4]+bestaudio[ext=m4a]/best[ext=mp4]/best" // Prioritize mp4 container
-)
+// videoSources is the registry of available VideoSource implementations,
+// keyed by the name used with --source. Call registerVideoSource from an
+// init function to make a new implementation selectable without touching
+// the pipeline.
+var videoSources = map[string]VideoSource{}
+
+func registerVideoSource(s VideoSource) {
+	videoSources[s.Name()] = s
+}
+
+// getVideoSource looks up a registered VideoSource by name.
+func getVideoSource(name string) (VideoSource, error) {
+	s, ok := videoSources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown video source %q (available: %s)", name, strings.Join(videoSourceNames(), ", "))
+	}
+	return s, nil
+}
+
+// videoSourceNames returns the registered source names, for error messages
+// and flag usage text.
+func videoSourceNames() []string {
+	names := make([]string, 0, len(videoSources))
+	for name := range videoSources {
+		names = append(names, name)
+	}
+	return names
+}
+
+func init() {
+	registerVideoSource(&YtDlpVideoSource{})
+	registerVideoSource(&KkdaiVideoSource{})
+}
 
 // getYoutubeVideoID extracts the video ID from rawURL.
 func getYoutubeVideoID(logger *slog.Logger, rawURL string) (string, error) {
@@ -52,10 +91,23 @@ func getYoutubeVideoID(logger *slog.Logger, rawURL string) (string, error) {
 	return videoID, nil
 }
 
-// downloadVideo uses yt-dlp to download the best quality video and audio.
-// It returns the path to the downloaded video file.
-func downloadVideo(ctx context.Context, logger *slog.Logger, videoID, url, workDir string) (string, error) {
-	logger = logger.With("step", "downloadVideo", "url", url)
+const (
+	ytDlpExecutable = "yt-dlp"
+
+	videoFormat = "bestvideo[ext=mp4]+bestaudio[ext=m4a]/best[ext=mp4]/best" // Prioritize mp4 container
+)
+
+// YtDlpVideoSource downloads video+audio by shelling out to the yt-dlp
+// binary. This is the original download path and remains the default since
+// it handles the widest range of formats and restrictions.
+type YtDlpVideoSource struct{}
+
+// Name implements VideoSource.
+func (s *YtDlpVideoSource) Name() string { return "yt-dlp" }
+
+// Download implements VideoSource.
+func (s *YtDlpVideoSource) Download(ctx context.Context, logger *slog.Logger, videoID, videoURL, workDir string) (string, error) {
+	logger = logger.With("step", "downloadVideo", "source", s.Name(), "url", videoURL)
 	logger.Info("Starting video download")
 
 	// Ensure yt-dlp exists
@@ -75,7 +127,7 @@ func downloadVideo(ctx context.Context, logger *slog.Logger, videoID, url, workD
 		"--progress",    // Show progress
 		"--no-warnings", // Suppress some common warnings
 		// "--verbose",     // Uncomment for debugging yt-dlp issues
-		url, // The video URL
+		videoURL, // The video URL
 	}
 
 	// Execute the command